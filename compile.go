@@ -0,0 +1,277 @@
+package deepcopy
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"unsafe"
+)
+
+// Copier is a reusable, pre-compiled copy plan for a specific
+// (srcType, dstType) pair, produced by Compile. Unlike Copy, which
+// re-walks struct tags and resolves the field matcher on every call,
+// (*Copier).Copy for a struct -> map[string]V pair reuses the field
+// layout Compile resolved; other pairs go through the same dynamic
+// engine as Copy.
+type Copier struct {
+	dstType reflect.Type
+	srcType reflect.Type
+	opts    *copyOptions
+	plan    *structPlan
+}
+
+// Compile builds a reusable Copier for copying values shaped like src
+// into values shaped like dst. dst must be a non-nil pointer, the same
+// as for Copy. The options passed here apply to every subsequent
+// (*Copier).Copy call.
+//
+// When src is a struct and dst points to a map[string]V, field layout
+// (offsets, resolved keys, required/unexported flags) is computed once
+// and cached process-wide, keyed by (srcType, dstType, FieldMatcher
+// value), so concurrent Compile calls for the same pair and matcher
+// share one plan. A FieldMatcher that is not comparable is not cached;
+// its plan is rebuilt on every Compile. Other type pairs fall back to the same dynamic engine
+// Copy uses; Compile still saves the per-call option resolution in that
+// case.
+func Compile(dst, src any, opts ...Option) (*Copier, error) {
+	o := newCopyOptions(opts)
+
+	dstType := reflect.TypeOf(dst)
+	if dstType == nil || dstType.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("dst must be a non-nil pointer: %w", ErrValueInvalid)
+	}
+	dstType = dstType.Elem()
+
+	srcType := reflect.TypeOf(src)
+	for srcType != nil && srcType.Kind() == reflect.Ptr {
+		srcType = srcType.Elem()
+	}
+	if srcType == nil {
+		return nil, fmt.Errorf("src must not be a nil interface: %w", ErrValueInvalid)
+	}
+
+	plan, err := getStructToMapPlan(dstType, srcType, o)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Copier{dstType: dstType, srcType: srcType, opts: o, plan: plan}, nil
+}
+
+// Copy runs the compiled plan against dst/src, which must have the
+// exact types Compile was called with.
+func (c *Copier) Copy(dst, src any) error {
+	dstVal := reflect.ValueOf(dst)
+	if dstVal.Kind() != reflect.Ptr || dstVal.IsNil() {
+		return fmt.Errorf("dst must be a non-nil pointer: %w", ErrValueInvalid)
+	}
+	dstElem := dstVal.Elem()
+	if dstElem.Type() != c.dstType {
+		return fmt.Errorf("dst type %s does not match compiled type %s: %w", dstElem.Type(), c.dstType, ErrValueInvalid)
+	}
+
+	srcVal := reflect.ValueOf(src)
+	for srcVal.Kind() == reflect.Ptr {
+		if srcVal.IsNil() {
+			return nil
+		}
+		srcVal = srcVal.Elem()
+	}
+	if srcVal.Type() != c.srcType {
+		return fmt.Errorf("src type %s does not match compiled type %s: %w", srcVal.Type(), c.srcType, ErrValueInvalid)
+	}
+
+	state := &copyState{}
+	if c.plan.unsupported {
+		return copyValue(dstElem, srcVal, c.opts, state)
+	}
+	return c.plan.apply(dstElem, srcVal, c.opts, state)
+}
+
+// planCacheKey identifies a compiled struct-to-map field layout. The
+// FieldMatcher itself (not just its type) is part of the key, since a
+// matcher can carry its own configuration (e.g. TagMatcher.Tag) that
+// affects the resolved key for untagged fields just as much as which
+// matcher type it is.
+type planCacheKey struct {
+	dstType, srcType reflect.Type
+	matcher          any
+}
+
+// planCache holds one *structPlan per planCacheKey. Concurrent first
+// calls for the same key may each build a plan, but LoadOrStore keeps
+// only one and every caller gets that one.
+var planCache sync.Map
+
+// noFastPathPlan marks a (srcType, dstType) pair that Compile's fast
+// path does not (yet) support; (*Copier).Copy falls back to the
+// ordinary dynamic engine for it.
+var noFastPathPlan = &structPlan{unsupported: true}
+
+// structPlan is the compiled field layout for copying one struct type
+// into one map type.
+type structPlan struct {
+	unsupported bool
+	fields      []planField
+}
+
+// planField is a single flattened (embedding-resolved) source field:
+// its byte offset from the start of the root struct, its resolved
+// destination key, and the flags needed to replicate the dynamic
+// engine's required/unexported handling.
+type planField struct {
+	path       []int
+	offset     uintptr
+	fieldType  reflect.Type
+	key        string
+	required   bool
+	unexported bool
+}
+
+// getStructToMapPlan returns the cached plan for srcType -> dstType
+// under opts.matcher, building and storing it on first use. When
+// opts.matcher isn't comparable (so it can't safely be used as a map
+// key), the plan is built fresh every call instead of being cached.
+func getStructToMapPlan(dstType, srcType reflect.Type, opts *copyOptions) (*structPlan, error) {
+	if srcType.Kind() != reflect.Struct || dstType.Kind() != reflect.Map || dstType.Key().Kind() != reflect.String {
+		return noFastPathPlan, nil
+	}
+
+	if !reflect.TypeOf(opts.matcher).Comparable() {
+		return buildStructToMapPlan(srcType, opts)
+	}
+
+	key := planCacheKey{dstType: dstType, srcType: srcType, matcher: opts.matcher}
+	if cached, ok := planCache.Load(key); ok {
+		return cached.(*structPlan), nil
+	}
+
+	plan, err := buildStructToMapPlan(srcType, opts)
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := planCache.LoadOrStore(key, plan)
+	return actual.(*structPlan), nil
+}
+
+// buildStructToMapPlan flattens srcType's fields under opts into a fresh
+// structPlan, without consulting or populating planCache.
+func buildStructToMapPlan(srcType reflect.Type, opts *copyOptions) (*structPlan, error) {
+	var fields []planField
+	supported, err := flattenStructFields(srcType, srcType, nil, opts, &fields)
+	if err != nil {
+		return nil, err
+	}
+	if !supported {
+		return noFastPathPlan, nil
+	}
+	return &structPlan{fields: fields}, nil
+}
+
+// flattenStructFields walks curType's fields (recursing into anonymous
+// struct embeds), appending one planField per tag-visible field. It
+// returns false when it encounters something the fast path can't
+// represent (currently: a nil-able anonymous struct pointer), signaling
+// the caller to fall back to the dynamic engine for the whole type.
+func flattenStructFields(rootType, curType reflect.Type, prefix []int, opts *copyOptions, out *[]planField) (bool, error) {
+	for i := 0; i < curType.NumField(); i++ {
+		field := curType.Field(i)
+		path := make([]int, len(prefix)+1)
+		copy(path, prefix)
+		path[len(prefix)] = i
+
+		if field.Anonymous {
+			if field.Type.Kind() == reflect.Struct {
+				if ok, err := flattenStructFields(rootType, field.Type, path, opts, out); err != nil || !ok {
+					return ok, err
+				}
+				continue
+			}
+			if field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Struct {
+				return false, nil
+			}
+		}
+
+		tag := parseFieldTag(opts.matcher.FieldName(field), field.Tag.Get("copy"))
+		if tag.ignore {
+			continue
+		}
+
+		*out = append(*out, planField{
+			path:       path,
+			offset:     cumulativeFieldOffset(rootType, path),
+			fieldType:  field.Type,
+			key:        tag.name,
+			required:   tag.required,
+			unexported: field.PkgPath != "",
+		})
+	}
+	return true, nil
+}
+
+// cumulativeFieldOffset sums each level's StructField.Offset along path
+// to get the field's byte offset from the start of the root struct, so
+// it can be reached with a single unsafe.Pointer addition regardless of
+// how many anonymous structs it is embedded through.
+func cumulativeFieldOffset(t reflect.Type, path []int) uintptr {
+	var total uintptr
+	cur := t
+	for _, idx := range path {
+		sf := cur.Field(idx)
+		total += sf.Offset
+		cur = sf.Type
+	}
+	return total
+}
+
+// apply executes the compiled plan: dst is initialized if nil, then
+// each field is read via its precomputed offset (when src is
+// addressable) or via its index path (for exported fields on an
+// unaddressable src), converted, and stored under its resolved key.
+// Getter methods registered via WithMethods still go through
+// copyStructMethodsToMap, since their resolution isn't part of the
+// cached plan.
+func (p *structPlan) apply(dst, src reflect.Value, opts *copyOptions, state *copyState) error {
+	if dst.IsNil() {
+		dst.Set(reflect.MakeMap(dst.Type()))
+	}
+
+	hasAddr := src.CanAddr()
+	var base unsafe.Pointer
+	if hasAddr {
+		base = unsafe.Pointer(src.UnsafeAddr())
+	}
+
+	keyType := dst.Type().Key()
+	valType := dst.Type().Elem()
+
+	for _, f := range p.fields {
+		var readable reflect.Value
+		switch {
+		case hasAddr:
+			readable = reflect.NewAt(f.fieldType, unsafe.Pointer(uintptr(base)+f.offset)).Elem()
+		case f.unexported:
+			if f.required {
+				return fmt.Errorf("field %q: %w", f.key, ErrValueUnaddressable)
+			}
+			continue
+		default:
+			readable = src.FieldByIndex(f.path)
+		}
+
+		valSlot := reflect.New(valType).Elem()
+		if err := copyValue(valSlot, readable, opts, state); err != nil {
+			if errors.Is(err, errStreamSkip) {
+				continue
+			}
+			if classified := classifyFieldError(err, f.unexported, f.required, opts.ignoreNonCopyableTypes); classified != nil {
+				return fmt.Errorf("field %q: %w", f.key, classified)
+			}
+			continue
+		}
+		dst.SetMapIndex(reflect.ValueOf(f.key).Convert(keyType), valSlot)
+	}
+
+	return copyStructMethodsToMap(dst, src, opts, state)
+}
@@ -0,0 +1,121 @@
+package deepcopy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Compile(t *testing.T) {
+	t.Run("#1: simple case", func(t *testing.T) {
+		type SS struct {
+			I int
+			U uint
+		}
+
+		var d map[string]int
+		c, err := Compile(&d, SS{})
+		assert.Nil(t, err)
+
+		err = c.Copy(&d, SS{I: 1, U: 2})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]int{"I": 1, "U": 2}, d)
+	})
+
+	t.Run("#2: reused across calls with different data", func(t *testing.T) {
+		type SS struct {
+			I int `copy:"i"`
+		}
+
+		var d map[string]int
+		c, err := Compile(&d, SS{})
+		assert.Nil(t, err)
+
+		assert.Nil(t, c.Copy(&d, SS{I: 1}))
+		assert.Equal(t, map[string]int{"i": 1}, d)
+
+		d = nil
+		assert.Nil(t, c.Copy(&d, SS{I: 42}))
+		assert.Equal(t, map[string]int{"i": 42}, d)
+	})
+
+	t.Run("#3: deep embedded struct field", func(t *testing.T) {
+		type SS3 struct {
+			I int `copy:"i"`
+		}
+		type SS2 struct {
+			SS3
+		}
+		type SS struct {
+			SS2
+		}
+
+		var d map[string]int
+		c, err := Compile(&d, SS{})
+		assert.Nil(t, err)
+
+		err = c.Copy(&d, SS{SS2: SS2{SS3: SS3{I: 1}}})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]int{"i": 1}, d)
+	})
+
+	t.Run("#4: unexported required field, unaddressable src", func(t *testing.T) {
+		type SS struct {
+			I int
+			u uint `copy:"u,required"`
+		}
+
+		var d map[string]int
+		c, err := Compile(&d, SS{})
+		assert.Nil(t, err)
+
+		err = c.Copy(&d, SS{I: 1, u: 2}) // passed by value: unaddressable
+		assert.ErrorIs(t, err, ErrValueUnaddressable)
+	})
+
+	t.Run("#5: unexported required field, addressable src", func(t *testing.T) {
+		type SS struct {
+			I int
+			u uint `copy:"u,required"`
+		}
+
+		var d map[string]int
+		c, err := Compile(&d, SS{})
+		assert.Nil(t, err)
+
+		s := SS{I: 1, u: 2}
+		err = c.Copy(&d, &s)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]int{"I": 1, "u": 2}, d)
+	})
+
+	t.Run("#6: type mismatch is rejected", func(t *testing.T) {
+		type SS struct{ I int }
+		type Other struct{ I int }
+
+		var d map[string]int
+		c, err := Compile(&d, SS{})
+		assert.Nil(t, err)
+
+		err = c.Copy(&d, Other{I: 1})
+		assert.ErrorIs(t, err, ErrValueInvalid)
+	})
+
+	t.Run("#7: plans for the same struct don't collide across differently-configured TagMatchers", func(t *testing.T) {
+		type SS struct {
+			UserID int `json:"uid" xml:"user_id"`
+		}
+
+		var dJSON map[string]int
+		cJSON, err := Compile(&dJSON, SS{}, WithFieldMatcher(TagMatcher{Tag: "json"}))
+		assert.Nil(t, err)
+		assert.Nil(t, cJSON.Copy(&dJSON, SS{UserID: 7}))
+		assert.Equal(t, map[string]int{"uid": 7}, dJSON)
+
+		var dXML map[string]int
+		cXML, err := Compile(&dXML, SS{}, WithFieldMatcher(TagMatcher{Tag: "xml"}))
+		assert.Nil(t, err)
+		assert.Nil(t, cXML.Copy(&dXML, SS{UserID: 7}))
+		assert.Equal(t, map[string]int{"user_id": 7}, dXML)
+	})
+}
@@ -0,0 +1,73 @@
+package deepcopy
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// TypeConverter lets callers bridge otherwise ErrTypeNonCopyable
+// src/dst type pairs (e.g. time.Time -> string, []byte -> string) with
+// custom conversion logic, without patching the built-in copiers.
+type TypeConverter struct {
+	SrcType, DstType interface{}
+	Fn               func(src interface{}) (interface{}, error)
+}
+
+// converterKey identifies a registered TypeConverter by its exact
+// source and destination reflect.Type.
+type converterKey struct {
+	src, dst reflect.Type
+}
+
+// WithConverters registers one or more TypeConverters to be consulted
+// before the built-in kind-to-kind copy rules. Later converters for the
+// same (SrcType, DstType) pair override earlier ones.
+func WithConverters(converters ...TypeConverter) Option {
+	return func(o *copyOptions) {
+		if o.converters == nil {
+			o.converters = make(map[converterKey]TypeConverter, len(converters))
+		}
+		for _, c := range converters {
+			o.converters[converterKey{
+				src: reflect.TypeOf(c.SrcType),
+				dst: reflect.TypeOf(c.DstType),
+			}] = c
+		}
+	}
+}
+
+// lookupConverter returns the converter registered for the exact
+// (srcType, dstType) pair, if any. The map lookup keeps this O(1)
+// regardless of how many converters are registered.
+func lookupConverter(opts *copyOptions, srcType, dstType reflect.Type) (TypeConverter, bool) {
+	if opts.converters == nil {
+		return TypeConverter{}, false
+	}
+	c, ok := opts.converters[converterKey{src: srcType, dst: dstType}]
+	return c, ok
+}
+
+// applyConverter runs a matched TypeConverter and stores its result
+// into dst, converting the returned value's type to dst's type when
+// they are not already identical (e.g. a converter returning int for a
+// MyInt destination).
+func applyConverter(c TypeConverter, dst, src reflect.Value) error {
+	out, err := c.Fn(src.Interface())
+	if err != nil {
+		return err
+	}
+	if out == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	outVal := reflect.ValueOf(out)
+	if outVal.Type() != dst.Type() {
+		if !outVal.Type().ConvertibleTo(dst.Type()) {
+			return fmt.Errorf("converter result %s -> %s: %w", outVal.Type(), dst.Type(), ErrTypeNonCopyable)
+		}
+		outVal = outVal.Convert(dst.Type())
+	}
+	dst.Set(outVal)
+	return nil
+}
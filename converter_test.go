@@ -0,0 +1,83 @@
+package deepcopy
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Copy_WithConverters(t *testing.T) {
+	t.Run("#1: bridges time.Time to string", func(t *testing.T) {
+		type SS struct {
+			At time.Time
+		}
+
+		conv := TypeConverter{
+			SrcType: time.Time{},
+			DstType: "",
+			Fn: func(src interface{}) (interface{}, error) {
+				return src.(time.Time).Format(time.RFC3339), nil
+			},
+		}
+
+		at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		s := SS{At: at}
+		var d map[string]string
+		err := Copy(&d, s, WithConverters(conv))
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{"At": at.Format(time.RFC3339)}, d)
+	})
+
+	t.Run("#2: bridges []byte to string", func(t *testing.T) {
+		type SS struct {
+			Raw []byte
+		}
+
+		conv := TypeConverter{
+			SrcType: []byte(nil),
+			DstType: "",
+			Fn: func(src interface{}) (interface{}, error) {
+				return string(src.([]byte)), nil
+			},
+		}
+
+		s := SS{Raw: []byte("hello")}
+		var d map[string]string
+		err := Copy(&d, s, WithConverters(conv))
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{"Raw": "hello"}, d)
+	})
+
+	t.Run("#3: propagates converter error", func(t *testing.T) {
+		type SS struct {
+			S string
+		}
+
+		boom := errors.New("boom")
+		conv := TypeConverter{
+			SrcType: "",
+			DstType: int(0),
+			Fn: func(src interface{}) (interface{}, error) {
+				return nil, boom
+			},
+		}
+
+		s := SS{S: "abc"}
+		var d map[string]int
+		err := Copy(&d, s, WithConverters(conv))
+		assert.ErrorIs(t, err, boom)
+	})
+
+	t.Run("#4: without a matching converter, falls back to non-copyable error", func(t *testing.T) {
+		type SS struct {
+			S string
+		}
+
+		s := SS{S: "abc"}
+		var d map[string]int
+		err := Copy(&d, s)
+		assert.ErrorIs(t, err, ErrTypeNonCopyable)
+	})
+}
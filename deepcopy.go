@@ -0,0 +1,194 @@
+// Package deepcopy copies values between structs, maps, slices and
+// scalars using reflection, reconciling mismatched-but-compatible types
+// (e.g. int -> int8, *int -> int, struct -> map[string]any) along the
+// way.
+package deepcopy
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Copy deep-copies src into dst. dst must be a non-nil pointer.
+//
+// Struct fields may carry a `copy:"..."` tag to rename the destination
+// key, skip the field (`copy:"-"`), or mark it as required (see
+// parseFieldTag). Unexported fields are copied on a best-effort basis
+// unless tagged as required, in which case an unaddressable source
+// causes Copy to return ErrValueUnaddressable. Copy tags are read only
+// when copying between a struct and a map; struct -> struct copies match
+// fields by index or Go name and ignore them.
+func Copy(dst, src any, opts ...Option) error {
+	o := newCopyOptions(opts)
+
+	dstVal := reflect.ValueOf(dst)
+	if dstVal.Kind() != reflect.Ptr || dstVal.IsNil() {
+		return fmt.Errorf("dst must be a non-nil pointer: %w", ErrValueInvalid)
+	}
+
+	srcVal := reflect.ValueOf(src)
+	if srcVal.Kind() == reflect.Ptr && srcVal.IsNil() {
+		return nil
+	}
+
+	// srcVal is passed through as-is, pointer levels and all, rather than
+	// dereferenced here: copyValue's own Ptr handling is what registers a
+	// src pointer in state.visited before recursing into it, so if src
+	// itself is a pointer the root participates in identity preservation
+	// just like any pointer reachable from it (e.g. a self-referential
+	// root where src == src.Next ends up with dst == dst.Next too).
+	return copyValue(dstVal.Elem(), srcVal, o, &copyState{})
+}
+
+// copyValue is the central dispatch point: it resolves src down to a
+// concrete value, consults any registered TypeConverter, and otherwise
+// routes to the copier matching the src/dst kind pair. state tracks
+// pointers/slices/maps already visited during this top-level Copy call,
+// so shared and cyclic structure can be reunified instead of recursing
+// forever (see PreserveIdentity). Stream-like values (see StreamPolicy)
+// are handled before interfaces are unwrapped, since it's src's declared
+// type, not its dynamic type, that marks it as a stream.
+func copyValue(dst, src reflect.Value, opts *copyOptions, state *copyState) error {
+	if !src.IsValid() {
+		return nil
+	}
+
+	if isStreamType(src.Type()) {
+		if conv, ok := lookupConverter(opts, src.Type(), dst.Type()); ok {
+			return applyConverter(conv, dst, src)
+		}
+		return applyStreamPolicy(dst, src, opts)
+	}
+
+	for src.Kind() == reflect.Interface && !src.IsNil() {
+		src = src.Elem()
+	}
+	if src.Kind() == reflect.Interface {
+		// The loop above only stops on Interface kind when src is nil
+		// (e.g. an untouched `any` field): there's nothing to copy, and
+		// falling through would re-wrap this same nil interface forever
+		// in the dst-is-interface branch below.
+		return nil
+	}
+
+	if conv, ok := lookupConverter(opts, src.Type(), dst.Type()); ok {
+		return applyConverter(conv, dst, src)
+	}
+
+	switch src.Kind() {
+	case reflect.UnsafePointer, reflect.Chan, reflect.Func:
+		return fmt.Errorf("%s: %w", src.Type(), ErrTypeNonCopyable)
+	}
+
+	if dst.Kind() == reflect.Interface {
+		cp := reflect.New(src.Type()).Elem()
+		if err := copyValue(cp, src, opts, state); err != nil {
+			return err
+		}
+		dst.Set(cp)
+		return nil
+	}
+
+	if src.Kind() == reflect.Ptr {
+		if src.IsNil() {
+			return nil
+		}
+		if dst.Kind() == reflect.Ptr {
+			if opts.preserveIdentity {
+				key := visitedKey{ptr: src.Pointer(), typ: src.Type()}
+				if existing, ok := state.visited[key]; ok {
+					dst.Set(existing)
+					return nil
+				}
+				newDst := reflect.New(dst.Type().Elem())
+				state.rememberVisited(key, newDst)
+				if err := copyValue(newDst.Elem(), src.Elem(), opts, state); err != nil {
+					return err
+				}
+				dst.Set(newDst)
+				return nil
+			}
+			newDst := reflect.New(dst.Type().Elem())
+			if err := copyValue(newDst.Elem(), src.Elem(), opts, state); err != nil {
+				return err
+			}
+			dst.Set(newDst)
+			return nil
+		}
+		return copyValue(dst, src.Elem(), opts, state)
+	}
+
+	if dst.Kind() == reflect.Ptr {
+		newDst := reflect.New(dst.Type().Elem())
+		if err := copyValue(newDst.Elem(), src, opts, state); err != nil {
+			return err
+		}
+		dst.Set(newDst)
+		return nil
+	}
+
+	switch {
+	case src.Kind() == reflect.Struct && dst.Kind() == reflect.Map:
+		return copyStructToMap(dst, src, opts, state)
+	case src.Kind() == reflect.Struct && dst.Kind() == reflect.Struct:
+		return copyStructToStruct(dst, src, opts, state)
+	case src.Kind() == reflect.Slice && dst.Kind() == reflect.Slice:
+		return copySliceToSlice(dst, src, opts, state)
+	case src.Kind() == reflect.Map && dst.Kind() == reflect.Map:
+		return copyMapToMap(dst, src, opts, state)
+	case src.Kind() == reflect.Map && dst.Kind() == reflect.Struct:
+		return copyMapToStruct(dst, src, opts, state)
+	}
+
+	if src.Type() == dst.Type() {
+		dst.Set(src)
+		return nil
+	}
+	if isNumericKind(src.Kind()) && isNumericKind(dst.Kind()) && src.Type().ConvertibleTo(dst.Type()) {
+		dst.Set(src.Convert(dst.Type()))
+		return nil
+	}
+	if src.Kind() == reflect.String && dst.Kind() == reflect.String && src.Type().ConvertibleTo(dst.Type()) {
+		dst.Set(src.Convert(dst.Type()))
+		return nil
+	}
+
+	return fmt.Errorf("%s -> %s: %w", src.Type(), dst.Type(), ErrTypeNonCopyable)
+}
+
+// isNumericKind reports whether k is a bool/int/uint/float/complex kind
+// eligible for lossy numeric conversion (e.g. int -> int8, uint ->
+// float32). String is deliberately excluded: numeric<->string round
+// trips are handled by TypeConverter, not implicit conversion.
+func isNumericKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Complex64, reflect.Complex128:
+		return true
+	default:
+		return false
+	}
+}
+
+// classifyFieldError applies the shared required/ignore/unexported
+// policy to an error produced while copying a single struct field. It
+// returns nil when the field should simply be skipped, or the error
+// that should abort the whole Copy.
+func classifyFieldError(err error, isUnexported, required, ignoreNonCopyable bool) error {
+	if err == nil {
+		return nil
+	}
+	if isUnexported && !required {
+		return nil
+	}
+	if required {
+		return fmt.Errorf("%w: %v", ErrFieldRequireCopying, err)
+	}
+	if ignoreNonCopyable {
+		return nil
+	}
+	return err
+}
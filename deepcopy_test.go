@@ -0,0 +1,28 @@
+package deepcopy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Copy_nilValues(t *testing.T) {
+	t.Run("#1: nil src is a no-op, not a panic", func(t *testing.T) {
+		var d map[string]any
+		err := Copy(&d, nil)
+		assert.Nil(t, err)
+	})
+
+	t.Run("#2: nil any field copies as a nil/absent value instead of recursing forever", func(t *testing.T) {
+		type SS struct {
+			I int
+			A any
+		}
+
+		var d map[string]any
+		err := Copy(&d, SS{I: 1})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, d["I"])
+		assert.Nil(t, d["A"])
+	})
+}
@@ -0,0 +1,26 @@
+package deepcopy
+
+import "errors"
+
+// Sentinel errors returned (possibly wrapped) by Copy and its internal
+// copiers. Callers should use errors.Is to check against these rather
+// than comparing error values directly.
+var (
+	// ErrValueInvalid is returned when dst is not a non-nil pointer.
+	ErrValueInvalid = errors.New("deepcopy: value is invalid")
+
+	// ErrValueUnaddressable is returned when an unexported struct field
+	// marked as required cannot be read because the containing value is
+	// not addressable (e.g. it was passed by value rather than by pointer).
+	ErrValueUnaddressable = errors.New("deepcopy: value is unaddressable")
+
+	// ErrTypeNonCopyable is returned when the source and destination
+	// types cannot be reconciled by any of the built-in copiers (and no
+	// matching TypeConverter has been registered).
+	ErrTypeNonCopyable = errors.New("deepcopy: type is non-copyable")
+
+	// ErrFieldRequireCopying is returned when a struct field tagged
+	// `copy:",required"` could not be copied, even if the failure would
+	// otherwise have been ignored (e.g. via IgnoreNonCopyableTypes).
+	ErrFieldRequireCopying = errors.New("deepcopy: field requires copying")
+)
@@ -0,0 +1,7 @@
+package deepcopy
+
+// ptrOf returns a pointer to a copy of v, for building test fixtures
+// that need a literal pointer value inline.
+func ptrOf[T any](v T) *T {
+	return &v
+}
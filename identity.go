@@ -0,0 +1,30 @@
+package deepcopy
+
+import "reflect"
+
+// visitedKey identifies a source pointer, slice, or map value that has
+// already been copied during the current top-level Copy call, so a
+// second reference to it can reuse the destination produced the first
+// time instead of being expanded into a separate copy.
+type visitedKey struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// copyState carries the per-call mutable state that must survive across
+// recursive copyValue calls but must never be shared between separate
+// Copy calls (including repeated (*Copier).Copy calls reusing the same
+// *copyOptions). visited is lazily allocated since most copies involve
+// no shared/cyclic structure at all.
+type copyState struct {
+	visited map[visitedKey]reflect.Value
+}
+
+// rememberVisited records that key has already produced dst, so a
+// later reference to the same source value can be reunified with it.
+func (s *copyState) rememberVisited(key visitedKey, dst reflect.Value) {
+	if s.visited == nil {
+		s.visited = make(map[visitedKey]reflect.Value)
+	}
+	s.visited[key] = dst
+}
@@ -0,0 +1,73 @@
+package deepcopy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type idLeaf struct {
+	V int
+}
+
+type idPair struct {
+	A, B *idLeaf
+}
+
+func Test_Copy_PreserveIdentity(t *testing.T) {
+	t.Run("#1: shared pointer fields stay shared in dst", func(t *testing.T) {
+		leaf := &idLeaf{V: 1}
+		src := idPair{A: leaf, B: leaf}
+
+		var d idPair
+		err := Copy(&d, src)
+		assert.Nil(t, err)
+		assert.Same(t, d.A, d.B)
+		assert.NotSame(t, leaf, d.A)
+		assert.Equal(t, 1, d.A.V)
+	})
+
+	t.Run("#2: disabling PreserveIdentity expands shared pointers into separate copies", func(t *testing.T) {
+		leaf := &idLeaf{V: 1}
+		src := idPair{A: leaf, B: leaf}
+
+		var d idPair
+		err := Copy(&d, src, PreserveIdentity(false))
+		assert.Nil(t, err)
+		assert.NotSame(t, d.A, d.B)
+		assert.Equal(t, d.A, d.B)
+	})
+
+	t.Run("#3: true self-referential cycle terminates and preserves identity", func(t *testing.T) {
+		type node struct {
+			Name string
+			Next *node
+		}
+		a := &node{Name: "a"}
+		a.Next = a
+
+		var d *node
+		err := Copy(&d, a)
+		assert.Nil(t, err)
+		assert.Equal(t, "a", d.Name)
+		assert.Same(t, d, d.Next)
+	})
+
+	t.Run("#4: mutually-referencing cycle terminates and preserves identity", func(t *testing.T) {
+		type node struct {
+			Name string
+			Next *node
+		}
+		a := &node{Name: "a"}
+		b := &node{Name: "b"}
+		a.Next = b
+		b.Next = a
+
+		var d *node
+		err := Copy(&d, a)
+		assert.Nil(t, err)
+		assert.Equal(t, "a", d.Name)
+		assert.Equal(t, "b", d.Next.Name)
+		assert.Same(t, d, d.Next.Next)
+	})
+}
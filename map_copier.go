@@ -0,0 +1,44 @@
+package deepcopy
+
+import "reflect"
+
+// copyMapToMap deep-copies each entry of src into dst, initializing dst
+// if it is currently nil. When PreserveIdentity is on (the default), a
+// src map already seen during this Copy call reuses the map it produced
+// the first time instead of being copied again.
+func copyMapToMap(dst, src reflect.Value, opts *copyOptions, state *copyState) error {
+	if src.IsNil() {
+		return nil
+	}
+
+	if opts.preserveIdentity {
+		key := visitedKey{ptr: src.Pointer(), typ: src.Type()}
+		if existing, ok := state.visited[key]; ok {
+			dst.Set(existing)
+			return nil
+		}
+	}
+
+	if dst.IsNil() {
+		dst.Set(reflect.MakeMap(dst.Type()))
+	}
+	if opts.preserveIdentity {
+		state.rememberVisited(visitedKey{ptr: src.Pointer(), typ: src.Type()}, dst)
+	}
+
+	keyType := dst.Type().Key()
+	elemType := dst.Type().Elem()
+	iter := src.MapRange()
+	for iter.Next() {
+		key := reflect.New(keyType).Elem()
+		if err := copyValue(key, iter.Key(), opts, state); err != nil {
+			return err
+		}
+		val := reflect.New(elemType).Elem()
+		if err := copyValue(val, iter.Value(), opts, state); err != nil {
+			return err
+		}
+		dst.SetMapIndex(key, val)
+	}
+	return nil
+}
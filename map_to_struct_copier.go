@@ -0,0 +1,121 @@
+package deepcopy
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// copyMapToStruct populates dst's fields from matching keys in src, then
+// feeds any setter methods registered via WithMethods.
+func copyMapToStruct(dst, src reflect.Value, opts *copyOptions, state *copyState) error {
+	if err := copyMapToStructFields(dst, src, opts, state); err != nil {
+		return err
+	}
+	return copyMapToStructMethods(dst, src, opts, state)
+}
+
+// copyMapToStructFields walks dst's fields (flattening anonymous struct
+// fields) and, for each one whose `copy:"..."` key exists in src, copies
+// the corresponding map entry into it.
+func copyMapToStructFields(dst, src reflect.Value, opts *copyOptions, state *copyState) error {
+	keyType := src.Type().Key()
+	if keyType.Kind() != reflect.String {
+		return nil
+	}
+
+	dstType := dst.Type()
+	for i := 0; i < dstType.NumField(); i++ {
+		field := dstType.Field(i)
+		dstField := dst.Field(i)
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := copyMapToStructFields(dstField, src, opts, state); err != nil {
+				return err
+			}
+			continue
+		}
+
+		tag := parseFieldTag(opts.matcher.FieldName(field), field.Tag.Get("copy"))
+		if tag.ignore {
+			continue
+		}
+
+		val := src.MapIndex(reflect.ValueOf(tag.name).Convert(keyType))
+		if !val.IsValid() {
+			continue
+		}
+
+		isUnexported := field.PkgPath != ""
+		var writable reflect.Value
+		if isUnexported {
+			if !dstField.CanAddr() {
+				if tag.required {
+					return fmt.Errorf("field %q: %w", field.Name, ErrValueUnaddressable)
+				}
+				continue
+			}
+			writable = reflect.NewAt(field.Type, unsafe.Pointer(dstField.UnsafeAddr())).Elem()
+		} else {
+			writable = dstField
+		}
+
+		if err := copyValue(writable, val, opts, state); err != nil {
+			if classified := classifyFieldError(err, isUnexported, tag.required, opts.ignoreNonCopyableTypes); classified != nil {
+				return fmt.Errorf("field %q: %w", field.Name, classified)
+			}
+		}
+	}
+	return nil
+}
+
+// copyMapToStructMethods calls each single-argument setter method
+// registered via WithMethods with the value found under its registered
+// map key, if any.
+func copyMapToStructMethods(dst, src reflect.Value, opts *copyOptions, state *copyState) error {
+	if len(opts.methods) == 0 {
+		return nil
+	}
+	keyType := src.Type().Key()
+	if keyType.Kind() != reflect.String {
+		return nil
+	}
+
+	for _, m := range opts.methods {
+		methodVal := reflect.Value{}
+		if dst.CanAddr() {
+			methodVal = dst.Addr().MethodByName(m.Method)
+		}
+		if !methodVal.IsValid() {
+			methodVal = dst.MethodByName(m.Method)
+		}
+		if !methodVal.IsValid() {
+			continue
+		}
+		mt := methodVal.Type()
+		if mt.NumIn() != 1 || mt.NumOut() > 1 {
+			continue
+		}
+
+		val := src.MapIndex(reflect.ValueOf(m.Name).Convert(keyType))
+		if !val.IsValid() {
+			continue
+		}
+
+		arg := reflect.New(mt.In(0)).Elem()
+		if err := copyValue(arg, val, opts, state); err != nil {
+			if classified := classifyFieldError(err, false, m.Required, opts.ignoreNonCopyableTypes); classified != nil {
+				return fmt.Errorf("method %q: %w", m.Method, classified)
+			}
+			continue
+		}
+
+		out := methodVal.Call([]reflect.Value{arg})
+		if len(out) == 1 {
+			if errVal, ok := out[0].Interface().(error); ok && errVal != nil {
+				return fmt.Errorf("method %q: %w", m.Method, errVal)
+			}
+		}
+	}
+	return nil
+}
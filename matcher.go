@@ -0,0 +1,90 @@
+package deepcopy
+
+import (
+	"strings"
+	"unicode"
+
+	"reflect"
+)
+
+// FieldMatcher computes the default map key for a struct field when it
+// carries no explicit `copy:"name"` tag. Built-in implementations cover
+// the common conventions; TagMatcher lets an existing tag (e.g. `json`)
+// be reused instead of duplicating it as `copy:"..."`.
+type FieldMatcher interface {
+	FieldName(field reflect.StructField) string
+}
+
+// ExactMatcher is the default FieldMatcher: the destination key is the
+// field's Go name, unchanged.
+type ExactMatcher struct{}
+
+// FieldName implements FieldMatcher.
+func (ExactMatcher) FieldName(field reflect.StructField) string {
+	return field.Name
+}
+
+// CaseInsensitiveMatcher lower-cases the field's Go name, e.g. "UserID"
+// -> "userid".
+type CaseInsensitiveMatcher struct{}
+
+// FieldName implements FieldMatcher.
+func (CaseInsensitiveMatcher) FieldName(field reflect.StructField) string {
+	return strings.ToLower(field.Name)
+}
+
+// SnakeCaseMatcher converts the field's Go name to snake_case, e.g.
+// "UserID" -> "user_id".
+type SnakeCaseMatcher struct{}
+
+// FieldName implements FieldMatcher.
+func (SnakeCaseMatcher) FieldName(field reflect.StructField) string {
+	return toSnakeCase(field.Name)
+}
+
+func toSnakeCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := i > 0 && !unicode.IsUpper(runes[i-1])
+			nextLower := i+1 < len(runes) && !unicode.IsUpper(runes[i+1])
+			if i > 0 && (prevLower || nextLower) {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// TagMatcher reads the destination key from another struct tag (most
+// commonly `json`) so a struct doesn't need parallel `copy:"..."` tags
+// just to reshape itself into a JSON-like map.
+type TagMatcher struct {
+	Tag string
+}
+
+// FieldName implements FieldMatcher.
+func (m TagMatcher) FieldName(field reflect.StructField) string {
+	raw, ok := field.Tag.Lookup(m.Tag)
+	if !ok {
+		return field.Name
+	}
+	name := strings.Split(raw, ",")[0]
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+// WithFieldMatcher overrides the strategy used to compute a field's
+// default destination key when it carries no explicit `copy:"name"`
+// tag. The default is ExactMatcher.
+func WithFieldMatcher(m FieldMatcher) Option {
+	return func(o *copyOptions) {
+		o.matcher = m
+	}
+}
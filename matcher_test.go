@@ -0,0 +1,59 @@
+package deepcopy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Copy_structToMap_fieldMatcher(t *testing.T) {
+	t.Run("#1: CaseInsensitiveMatcher lower-cases keys", func(t *testing.T) {
+		type SS struct {
+			UserID int
+		}
+
+		s := SS{UserID: 7}
+		var d map[string]int
+		err := Copy(&d, s, WithFieldMatcher(CaseInsensitiveMatcher{}))
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]int{"userid": 7}, d)
+	})
+
+	t.Run("#2: SnakeCaseMatcher converts to snake_case", func(t *testing.T) {
+		type SS struct {
+			UserID   int
+			FullName string
+		}
+
+		s := SS{UserID: 7, FullName: "Ada Lovelace"}
+		var d map[string]any
+		err := Copy(&d, s, WithFieldMatcher(SnakeCaseMatcher{}))
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]any{"user_id": 7, "full_name": "Ada Lovelace"}, d)
+	})
+
+	t.Run("#3: TagMatcher reads json tag when no copy tag is set", func(t *testing.T) {
+		type SS struct {
+			UserID int    `json:"user_id"`
+			Name   string `json:"name" copy:"display_name"`
+		}
+
+		s := SS{UserID: 7, Name: "Ada"}
+		var d map[string]any
+		err := Copy(&d, s, WithFieldMatcher(TagMatcher{Tag: "json"}))
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]any{"user_id": 7, "display_name": "Ada"}, d)
+	})
+
+	t.Run("#4: default matcher preserves exact field name", func(t *testing.T) {
+		type SS struct {
+			UserID int
+		}
+
+		s := SS{UserID: 7}
+		var d map[string]int
+		err := Copy(&d, s)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]int{"UserID": 7}, d)
+	})
+}
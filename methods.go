@@ -0,0 +1,34 @@
+package deepcopy
+
+// MethodField registers a zero-argument getter method on a source
+// struct (for struct -> map copies) or a single-argument setter method
+// on a destination struct (for map -> struct copies) to be bridged to a
+// map key under Name. Methods cannot carry `copy:"..."` struct tags
+// directly, so WithMethods is the companion registration API for them.
+type MethodField struct {
+	// Method is the exported method name, e.g. "FullName".
+	Method string
+	// Name is the map key the method's value is read from/written to.
+	// Defaults to Method when empty.
+	Name string
+	// Required causes Copy to fail if the getter's value (or the
+	// setter's argument) cannot be copied, mirroring `copy:",required"`.
+	Required bool
+}
+
+// WithMethods registers getter/setter methods to bridge during
+// struct<->map copies. Later registrations for the same Method override
+// earlier ones.
+func WithMethods(methods ...MethodField) Option {
+	return func(o *copyOptions) {
+		if o.methods == nil {
+			o.methods = make(map[string]MethodField, len(methods))
+		}
+		for _, m := range methods {
+			if m.Name == "" {
+				m.Name = m.Method
+			}
+			o.methods[m.Method] = m
+		}
+	}
+}
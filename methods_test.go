@@ -0,0 +1,63 @@
+package deepcopy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ssWithFullName struct {
+	First string
+	Last  string
+}
+
+func (s ssWithFullName) FullName() string {
+	return s.First + " " + s.Last
+}
+
+func Test_Copy_structToMap_methods(t *testing.T) {
+	t.Run("#1: getter method bridged into map", func(t *testing.T) {
+		s := ssWithFullName{First: "Ada", Last: "Lovelace"}
+		var d map[string]string
+		err := Copy(&d, s, WithMethods(MethodField{Method: "FullName", Name: "full_name"}))
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{"First": "Ada", "Last": "Lovelace", "full_name": "Ada Lovelace"}, d)
+	})
+
+	t.Run("#2: unregistered methods are not copied", func(t *testing.T) {
+		s := ssWithFullName{First: "Ada", Last: "Lovelace"}
+		var d map[string]string
+		err := Copy(&d, s)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{"First": "Ada", "Last": "Lovelace"}, d)
+	})
+}
+
+type ssWithSetter struct {
+	first string
+	last  string
+}
+
+func (s *ssWithSetter) SetFullName(name string) {
+	for i := 0; i < len(name); i++ {
+		if name[i] == ' ' {
+			s.first, s.last = name[:i], name[i+1:]
+			return
+		}
+	}
+	s.first = name
+}
+
+func (s ssWithSetter) First() string { return s.first }
+func (s ssWithSetter) Last() string  { return s.last }
+
+func Test_Copy_mapToStruct_methods(t *testing.T) {
+	t.Run("#1: setter method fed from map entry", func(t *testing.T) {
+		src := map[string]string{"full_name": "Ada Lovelace"}
+		var d ssWithSetter
+		err := Copy(&d, src, WithMethods(MethodField{Method: "SetFullName", Name: "full_name"}))
+		assert.Nil(t, err)
+		assert.Equal(t, "Ada", d.First())
+		assert.Equal(t, "Lovelace", d.Last())
+	})
+}
@@ -0,0 +1,47 @@
+package deepcopy
+
+// copyOptions holds the resolved configuration for a single Copy call.
+// It is unexported; callers configure it via the Option functions below.
+type copyOptions struct {
+	ignoreNonCopyableTypes bool
+	preserveIdentity       bool
+	converters             map[converterKey]TypeConverter
+	methods                map[string]MethodField
+	matcher                FieldMatcher
+	streamPolicy           StreamPolicy
+}
+
+// Option configures the behavior of Copy.
+type Option func(o *copyOptions)
+
+// IgnoreNonCopyableTypes controls whether a struct field copied into or
+// out of a map whose type cannot be reconciled by any copier is silently
+// skipped (true) or causes Copy to return ErrTypeNonCopyable (false, the
+// default). Struct -> struct copies do not consult it. A field
+// tagged `copy:",required"` always causes an error regardless of this
+// setting.
+func IgnoreNonCopyableTypes(v bool) Option {
+	return func(o *copyOptions) {
+		o.ignoreNonCopyableTypes = v
+	}
+}
+
+// PreserveIdentity controls whether shared pointers, slices, and maps
+// reachable from src keep their shared identity in dst (true, the
+// default) or are independently expanded into separate copies (false),
+// i.e. tree-expansion semantics. Disabling it also turns a genuine
+// cyclic graph back into unbounded recursion, so only opt out when src
+// is known to be acyclic.
+func PreserveIdentity(v bool) Option {
+	return func(o *copyOptions) {
+		o.preserveIdentity = v
+	}
+}
+
+func newCopyOptions(opts []Option) *copyOptions {
+	o := &copyOptions{matcher: ExactMatcher{}, preserveIdentity: true}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
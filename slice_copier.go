@@ -0,0 +1,35 @@
+package deepcopy
+
+import "reflect"
+
+// copySliceToSlice deep-copies each element of src into a freshly
+// allocated slice, leaving dst nil when src is nil. When PreserveIdentity
+// is on (the default), a src slice already seen during this Copy call
+// (same element pointer, length and type) reuses the slice it produced
+// the first time instead of being copied again, so two fields holding
+// the same slice keep sharing one in dst.
+func copySliceToSlice(dst, src reflect.Value, opts *copyOptions, state *copyState) error {
+	if src.IsNil() {
+		return nil
+	}
+
+	if opts.preserveIdentity {
+		key := visitedKey{ptr: src.Pointer(), typ: src.Type()}
+		if existing, ok := state.visited[key]; ok && existing.Len() == src.Len() {
+			dst.Set(existing)
+			return nil
+		}
+	}
+
+	out := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+	if opts.preserveIdentity {
+		state.rememberVisited(visitedKey{ptr: src.Pointer(), typ: src.Type()}, out)
+	}
+	for i := 0; i < src.Len(); i++ {
+		if err := copyValue(out.Index(i), src.Index(i), opts, state); err != nil {
+			return err
+		}
+	}
+	dst.Set(out)
+	return nil
+}
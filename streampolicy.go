@@ -0,0 +1,96 @@
+package deepcopy
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// StreamPolicy controls how Copy handles fields that represent a live
+// stream or channel rather than data: fields typed as io.Reader,
+// io.Writer, or io.Closer (or any interface embedding one of them), and
+// fields of chan or func type. These can't be meaningfully deep-copied,
+// so by default Copy shares them instead of erroring.
+type StreamPolicy int
+
+const (
+	// StreamShare assigns the source value directly into the
+	// destination, sharing the same underlying stream/channel/func
+	// rather than attempting to copy it. This is the default.
+	StreamShare StreamPolicy = iota
+	// StreamError rejects stream-like fields with ErrTypeNonCopyable,
+	// subject to the usual required/IgnoreNonCopyableTypes rules. This
+	// was Copy's only behavior before StreamPolicy existed.
+	StreamError
+	// StreamSkip omits stream-like struct fields (and registered getter
+	// results) from a destination map entirely, regardless of
+	// IgnoreNonCopyableTypes or a `required` tag. Other copies do not
+	// omit them: there the skip is returned as an error, like any other
+	// value that cannot be copied.
+	StreamSkip
+)
+
+var (
+	readerType = reflect.TypeOf((*io.Reader)(nil)).Elem()
+	writerType = reflect.TypeOf((*io.Writer)(nil)).Elem()
+	closerType = reflect.TypeOf((*io.Closer)(nil)).Elem()
+)
+
+// errStreamSkip is returned by applyStreamPolicy under StreamSkip.
+// copyFieldToMap recognizes it and always omits the field, bypassing
+// classifyFieldError's required/ignore handling.
+var errStreamSkip = errors.New("deepcopy: stream field skipped")
+
+// isStreamType reports whether t is a chan, func, or an interface type
+// satisfying io.Reader, io.Writer, or io.Closer. It must be checked
+// against src's declared type before any interface is unwrapped to its
+// dynamic value, since the dynamic value's own type is usually a plain
+// struct.
+func isStreamType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Chan, reflect.Func:
+		return true
+	case reflect.Interface:
+		return t.Implements(readerType) || t.Implements(writerType) || t.Implements(closerType)
+	default:
+		return false
+	}
+}
+
+// applyStreamPolicy handles a stream-like src value per opts.streamPolicy.
+func applyStreamPolicy(dst, src reflect.Value, opts *copyOptions) error {
+	switch opts.streamPolicy {
+	case StreamSkip:
+		return errStreamSkip
+	case StreamError:
+		return fmt.Errorf("%s: %w", src.Type(), ErrTypeNonCopyable)
+	default:
+		if isNilable(src) && src.IsNil() {
+			return nil
+		}
+		if !src.Type().AssignableTo(dst.Type()) {
+			return fmt.Errorf("%s -> %s: %w", src.Type(), dst.Type(), ErrTypeNonCopyable)
+		}
+		dst.Set(src)
+		return nil
+	}
+}
+
+// isNilable reports whether v's Kind supports IsNil.
+func isNilable(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Interface, reflect.Chan, reflect.Func, reflect.Ptr, reflect.Map, reflect.Slice:
+		return true
+	default:
+		return false
+	}
+}
+
+// WithStreamPolicy sets how stream-like fields (see StreamPolicy) are
+// handled. The default is StreamShare.
+func WithStreamPolicy(p StreamPolicy) Option {
+	return func(o *copyOptions) {
+		o.streamPolicy = p
+	}
+}
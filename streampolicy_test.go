@@ -0,0 +1,58 @@
+package deepcopy
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type spSrc struct {
+	Body io.Reader
+	Ch   chan int
+}
+
+func Test_Copy_structToMap_streamPolicy(t *testing.T) {
+	t.Run("#1: StreamShare (default) shares the reader and channel", func(t *testing.T) {
+		body := strings.NewReader("hello")
+		ch := make(chan int)
+		src := spSrc{Body: body, Ch: ch}
+
+		dst := map[string]any{}
+		err := Copy(&dst, src)
+		assert.Nil(t, err)
+		assert.Same(t, body, dst["Body"])
+		assert.Equal(t, ch, dst["Ch"])
+	})
+
+	t.Run("#2: StreamError rejects the reader field", func(t *testing.T) {
+		src := spSrc{Body: strings.NewReader("hello")}
+
+		dst := map[string]any{}
+		err := Copy(&dst, src, WithStreamPolicy(StreamError))
+		assert.True(t, errors.Is(err, ErrTypeNonCopyable))
+	})
+
+	t.Run("#3: StreamSkip omits the reader and channel fields entirely", func(t *testing.T) {
+		src := spSrc{Body: strings.NewReader("hello"), Ch: make(chan int)}
+
+		dst := map[string]any{}
+		err := Copy(&dst, src, WithStreamPolicy(StreamSkip))
+		assert.Nil(t, err)
+		_, hasBody := dst["Body"]
+		_, hasCh := dst["Ch"]
+		assert.False(t, hasBody)
+		assert.False(t, hasCh)
+	})
+
+	t.Run("#4: nil reader field is shared as nil", func(t *testing.T) {
+		src := spSrc{}
+
+		dst := map[string]any{}
+		err := Copy(&dst, src)
+		assert.Nil(t, err)
+		assert.Nil(t, dst["Body"])
+	})
+}
@@ -0,0 +1,130 @@
+package deepcopy
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// copyStructToMap copies the tag-visible fields of a struct into a map
+// (unexported ones only when src is addressable), initializing dst if
+// it is currently nil, then
+// bridges any getter methods registered via WithMethods.
+func copyStructToMap(dst, src reflect.Value, opts *copyOptions, state *copyState) error {
+	if dst.IsNil() {
+		dst.Set(reflect.MakeMap(dst.Type()))
+	}
+	if err := copyStructFieldsToMap(dst, src, opts, state); err != nil {
+		return err
+	}
+	return copyStructMethodsToMap(dst, src, opts, state)
+}
+
+// copyStructMethodsToMap calls each zero-argument getter method
+// registered via WithMethods and stores its result under the
+// registered map key, the same way a tagged field would be.
+func copyStructMethodsToMap(dst, src reflect.Value, opts *copyOptions, state *copyState) error {
+	for _, m := range opts.methods {
+		methodVal := src.MethodByName(m.Method)
+		if !methodVal.IsValid() && src.CanAddr() {
+			methodVal = src.Addr().MethodByName(m.Method)
+		}
+		if !methodVal.IsValid() {
+			continue
+		}
+		mt := methodVal.Type()
+		if mt.NumIn() != 0 || mt.NumOut() != 1 {
+			continue
+		}
+
+		result := methodVal.Call(nil)[0]
+		tag := fieldTag{name: m.Name, required: m.Required}
+		if err := copyFieldToMap(dst, tag, result, false, opts, state); err != nil {
+			return fmt.Errorf("method %q: %w", m.Method, err)
+		}
+	}
+	return nil
+}
+
+// copyStructFieldsToMap walks the fields of src (flattening anonymous
+// struct/struct-pointer fields) and assigns each into dst under the key
+// resolved from its `copy:"..."` tag.
+func copyStructFieldsToMap(dst, src reflect.Value, opts *copyOptions, state *copyState) error {
+	srcType := src.Type()
+	for i := 0; i < srcType.NumField(); i++ {
+		field := srcType.Field(i)
+		fieldVal := src.Field(i)
+
+		if field.Anonymous {
+			if field.Type.Kind() == reflect.Struct {
+				if err := copyStructFieldsToMap(dst, fieldVal, opts, state); err != nil {
+					return err
+				}
+				continue
+			}
+			if field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Struct {
+				if fieldVal.IsNil() {
+					continue
+				}
+				if err := copyStructFieldsToMap(dst, fieldVal.Elem(), opts, state); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		tag := parseFieldTag(opts.matcher.FieldName(field), field.Tag.Get("copy"))
+		if tag.ignore {
+			continue
+		}
+
+		isUnexported := field.PkgPath != ""
+
+		var readable reflect.Value
+		if isUnexported {
+			if !fieldVal.CanAddr() {
+				if tag.required {
+					return fmt.Errorf("field %q: %w", field.Name, ErrValueUnaddressable)
+				}
+				continue
+			}
+			readable = reflect.NewAt(field.Type, unsafe.Pointer(fieldVal.UnsafeAddr())).Elem()
+		} else {
+			readable = fieldVal
+		}
+
+		if err := copyFieldToMap(dst, tag, readable, isUnexported, opts, state); err != nil {
+			return fmt.Errorf("field %q: %w", field.Name, err)
+		}
+	}
+	return nil
+}
+
+// copyFieldToMap resolves the destination key and value for a single
+// field and, on success, stores it into dst.
+func copyFieldToMap(dst reflect.Value, tag fieldTag, readable reflect.Value, isUnexported bool, opts *copyOptions, state *copyState) error {
+	keyType := dst.Type().Key()
+	if keyType.Kind() != reflect.String {
+		err := fmt.Errorf("map key type %s: %w", keyType, ErrTypeNonCopyable)
+		if classified := classifyFieldError(err, isUnexported, tag.required, opts.ignoreNonCopyableTypes); classified != nil {
+			return classified
+		}
+		return nil
+	}
+	key := reflect.ValueOf(tag.name).Convert(keyType)
+
+	valSlot := reflect.New(dst.Type().Elem()).Elem()
+	if err := copyValue(valSlot, readable, opts, state); err != nil {
+		if errors.Is(err, errStreamSkip) {
+			return nil
+		}
+		if classified := classifyFieldError(err, isUnexported, tag.required, opts.ignoreNonCopyableTypes); classified != nil {
+			return classified
+		}
+		return nil
+	}
+
+	dst.SetMapIndex(key, valSlot)
+	return nil
+}
@@ -0,0 +1,67 @@
+package deepcopy
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// copyStructToStruct deep-copies src into dst field by field. When both
+// share the exact same type, fields are matched by index (so embedded
+// unexported fields and duplicate names are handled correctly); otherwise
+// dst's fields are matched against src by Go field name.
+func copyStructToStruct(dst, src reflect.Value, opts *copyOptions, state *copyState) error {
+	if src.Type() == dst.Type() {
+		return copySameTypeStruct(dst, src, opts, state)
+	}
+
+	dstType := dst.Type()
+	for i := 0; i < dstType.NumField(); i++ {
+		dstField := dstType.Field(i)
+		srcFieldVal := src.FieldByName(dstField.Name)
+		if !srcFieldVal.IsValid() {
+			continue
+		}
+		writable := dst.Field(i)
+		if dstField.PkgPath != "" {
+			if !srcFieldVal.CanAddr() || !writable.CanAddr() {
+				continue
+			}
+			readable := reflect.NewAt(srcFieldVal.Type(), unsafe.Pointer(srcFieldVal.UnsafeAddr())).Elem()
+			writable = reflect.NewAt(dstField.Type, unsafe.Pointer(writable.UnsafeAddr())).Elem()
+			_ = copyValue(writable, readable, opts, state)
+			continue
+		}
+		if err := copyValue(writable, srcFieldVal, opts, state); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copySameTypeStruct(dst, src reflect.Value, opts *copyOptions, state *copyState) error {
+	t := src.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		isUnexported := field.PkgPath != ""
+
+		srcField := src.Field(i)
+		dstField := dst.Field(i)
+
+		readable, writable := srcField, dstField
+		if isUnexported {
+			if !srcField.CanAddr() || !dstField.CanAddr() {
+				continue
+			}
+			readable = reflect.NewAt(field.Type, unsafe.Pointer(srcField.UnsafeAddr())).Elem()
+			writable = reflect.NewAt(field.Type, unsafe.Pointer(dstField.UnsafeAddr())).Elem()
+		}
+
+		if err := copyValue(writable, readable, opts, state); err != nil {
+			if isUnexported {
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package deepcopy
+
+import "strings"
+
+// fieldTag is the parsed form of a `copy:"..."` struct tag.
+type fieldTag struct {
+	name     string
+	ignore   bool
+	required bool
+}
+
+// parseFieldTag parses the `copy:"..."` tag for a field whose default
+// key, as resolved by the FieldMatcher, is fieldName. Supported forms:
+//
+//	copy:"-"               // field is never copied
+//	copy:"name"             // use "name" as the destination key
+//	copy:",required"        // keep the default name, but fail if uncopyable
+//	copy:"name,required"    // combine a custom name with required
+//
+// When tag is empty, fieldName is used as-is.
+func parseFieldTag(fieldName, tag string) fieldTag {
+	ft := fieldTag{name: fieldName}
+	if tag == "" {
+		return ft
+	}
+	if tag == "-" {
+		ft.ignore = true
+		return ft
+	}
+
+	parts := strings.Split(tag, ",")
+	if parts[0] != "" {
+		ft.name = parts[0]
+	}
+	for _, opt := range parts[1:] {
+		if opt == "required" {
+			ft.required = true
+		}
+	}
+	return ft
+}